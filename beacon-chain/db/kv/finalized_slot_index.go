@@ -0,0 +1,99 @@
+package kv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// errFinalizedSlotIndexBucketMissing is returned when finalizedSlotIndexBucket
+// hasn't been created yet. It should only ever surface for a DB opened by
+// something other than NewKVStore, since NewKVStore creates the bucket before
+// handing the store back to its caller.
+var errFinalizedSlotIndexBucketMissing = errors.New("finalized slot index bucket does not exist")
+
+// finalizedSlotIndexBucket maps a finalized slot directly to its canonical block
+// root: slot -> root, one entry per non-skip slot. It lets the cold, finalized
+// portion of the chain answer "what is the canonical root/last valid block at or
+// before slot X" in a single bucket lookup instead of a BlockRoots range scan
+// over an entire epoch.
+var finalizedSlotIndexBucket = []byte("finalized-slot-index")
+
+// SaveFinalizedSlotIndex records the canonical block root for a finalized,
+// non-skip slot. It is called as part of migrating a state to the cold section
+// of the DB, alongside saving that state. ReindexFinalizedSlotIndex writes
+// entries without saving states, and a checkpoint sync anchor's state is saved
+// without an entry here.
+func (k *Store) SaveFinalizedSlotIndex(ctx context.Context, slot uint64, blockRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveFinalizedSlotIndex")
+	defer span.End()
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(finalizedSlotIndexBucket)
+		if bkt == nil {
+			return errFinalizedSlotIndexBucketMissing
+		}
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(slot), blockRoot[:])
+	})
+}
+
+// CanonicalBlockRootBySlot returns the canonical block root stored for slot, or
+// the zero root and false if slot is a skip slot or isn't indexed yet.
+func (k *Store) CanonicalBlockRootBySlot(ctx context.Context, slot uint64) ([32]byte, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.CanonicalBlockRootBySlot")
+	defer span.End()
+
+	var root [32]byte
+	var found bool
+	err := k.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(finalizedSlotIndexBucket)
+		if bkt == nil {
+			return errFinalizedSlotIndexBucketMissing
+		}
+		v := bkt.Get(bytesutil.Uint64ToBytesBigEndian(slot))
+		if v == nil {
+			return nil
+		}
+		root = bytesutil.ToBytes32(v)
+		found = true
+		return nil
+	})
+	return root, found, err
+}
+
+// HighestCanonicalRootBefore returns the canonical block root and slot of the
+// highest indexed finalized slot that is less than or equal to slot. It walks
+// the finalized slot index backwards from slot using the bucket's cursor, which
+// is an O(log n) seek plus at most one step back (skip slots have no entry)
+// rather than a full BlockRoots range scan over the epoch.
+func (k *Store) HighestCanonicalRootBefore(ctx context.Context, slot uint64) ([32]byte, uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.HighestCanonicalRootBefore")
+	defer span.End()
+
+	var root [32]byte
+	var foundSlot uint64
+	err := k.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(finalizedSlotIndexBucket)
+		if bkt == nil {
+			return errFinalizedSlotIndexBucketMissing
+		}
+		c := bkt.Cursor()
+		k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(slot))
+		if k == nil {
+			// slot is past the last indexed entry, the highest entry is the last one.
+			k, v = c.Last()
+		} else if bytesutil.BytesToUint64BigEndian(k) > slot {
+			k, v = c.Prev()
+		}
+		if k == nil {
+			return nil
+		}
+		foundSlot = bytesutil.BytesToUint64BigEndian(k)
+		root = bytesutil.ToBytes32(v)
+		return nil
+	})
+	return root, foundSlot, err
+}
@@ -0,0 +1,51 @@
+package kv
+
+import (
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DatabaseFileName is the name of the bolt DB file on disk.
+const DatabaseFileName = "beaconchain.db"
+
+// Store is the persistent, bolt-backed beacon chain database.
+type Store struct {
+	db *bolt.DB
+}
+
+// createBuckets lists every top level bucket that must exist before the store
+// serves any reads or writes. Buckets are created once, up front, rather than
+// lazily on first use, so a fresh DB and an upgraded DB both start from the
+// same guaranteed state and callers never see a nil bucket.
+var createBuckets = [][]byte{
+	finalizedSlotIndexBucket,
+}
+
+// NewKVStore opens (creating it if necessary) the bolt DB file under dirPath
+// and ensures every bucket in createBuckets exists before returning.
+func NewKVStore(dirPath string) (*Store, error) {
+	db, err := bolt.Open(filepath.Join(dirPath, DatabaseFileName), 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	k := &Store{db: db}
+	if err := k.ensureBuckets(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// ensureBuckets creates any bucket in createBuckets that doesn't exist yet.
+// It is called once when the store is opened.
+func (k *Store) ensureBuckets() error {
+	return k.db.Update(func(tx *bolt.Tx) error {
+		for _, bkt := range createBuckets {
+			if _, err := tx.CreateBucketIfNotExists(bkt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
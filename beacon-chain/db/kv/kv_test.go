@@ -0,0 +1,27 @@
+package kv
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewKVStore_CreatesFinalizedSlotIndexBucket(t *testing.T) {
+	k, err := NewKVStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer k.db.Close()
+
+	root := [32]byte{'r'}
+	if err := k.SaveFinalizedSlotIndex(context.Background(), 5, root); err != nil {
+		t.Fatalf("SaveFinalizedSlotIndex failed on a freshly opened store: %v", err)
+	}
+
+	got, found, err := k.CanonicalBlockRootBySlot(context.Background(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || got != root {
+		t.Error("Expected the saved root to round-trip through a freshly opened store")
+	}
+}
@@ -0,0 +1,51 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ReindexFinalizedSlotIndex backfills the finalized slot index for a DB that
+// predates it. It walks every block root already stored in the legacy
+// BlockRoots bucket and records each one's slot -> root mapping so
+// HighestCanonicalRootBefore and CanonicalBlockRootBySlot work without a full
+// historical re-sync. Safe to run multiple times; existing entries are
+// overwritten with the same value.
+func (k *Store) ReindexFinalizedSlotIndex(ctx context.Context) error {
+	roots, err := k.BlockRoots(ctx, filters.NewFilter())
+	if err != nil {
+		return err
+	}
+
+	type slotRoot struct {
+		slot uint64
+		root [32]byte
+	}
+	entries := make([]slotRoot, 0, len(roots))
+	for _, r := range roots {
+		b, err := k.Block(ctx, r)
+		if err != nil {
+			return err
+		}
+		if b == nil || b.Block == nil {
+			continue
+		}
+		entries = append(entries, slotRoot{slot: b.Block.Slot, root: r})
+	}
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(finalizedSlotIndexBucket)
+		if bkt == nil {
+			return errFinalizedSlotIndexBucketMissing
+		}
+		for _, e := range entries {
+			if err := bkt.Put(bytesutil.Uint64ToBytesBigEndian(e.slot), e.root[:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,20 @@
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// CheckpointStateFlag defines a flag pointing at an SSZ-encoded, finalized
+	// BeaconState to bootstrap state gen from, bypassing full historical sync.
+	CheckpointStateFlag = &cli.StringFlag{
+		Name:  "checkpoint-state-file",
+		Usage: "Local path to an SSZ-encoded finalized BeaconState used as a checkpoint sync anchor",
+	}
+	// CheckpointBlockFlag defines a flag pointing at the SSZ-encoded
+	// SignedBeaconBlock matching CheckpointStateFlag's state root.
+	CheckpointBlockFlag = &cli.StringFlag{
+		Name:  "checkpoint-block-file",
+		Usage: "Local path to the SSZ-encoded SignedBeaconBlock matching --checkpoint-state-file",
+	}
+)
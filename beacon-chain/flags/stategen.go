@@ -0,0 +1,14 @@
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+// StategenReplayWorkersFlag controls how many goroutines stategen uses to
+// verify block signatures concurrently during ReplayBlocksParallel. A value
+// <= 0 falls back to GOMAXPROCS.
+var StategenReplayWorkersFlag = &cli.IntFlag{
+	Name:  "stategen-replay-workers",
+	Usage: "Number of concurrent workers stategen uses to verify block signatures while replaying state, defaults to GOMAXPROCS",
+	Value: 0,
+}
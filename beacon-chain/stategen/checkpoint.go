@@ -0,0 +1,63 @@
+package stategen
+
+import (
+	"context"
+	"errors"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// ErrNoPreAnchorState is returned when a caller asks state gen for hot state data
+// that would require history before the checkpoint sync anchor. A node bootstrapped
+// from a weak subjectivity checkpoint never has that history, so this is returned
+// instead of state gen dereferencing an empty result.
+var ErrNoPreAnchorState = errors.New("no state history before checkpoint sync anchor")
+
+// InitFromCheckpoint installs a trusted, finalized state and block as state gen's
+// hot/cold split point without requiring genesis or any of the blocks preceding it.
+// This is the entry point for checkpoint sync: a node started with a weak
+// subjectivity state and block bypasses full historical sync and starts producing
+// hot summaries from the anchor forward.
+func (s *State) InitFromCheckpoint(ctx context.Context, st *state.BeaconState, block *ethpb.SignedBeaconBlock) error {
+	if st == nil {
+		return errors.New("checkpoint state is nil")
+	}
+	if block == nil || block.Block == nil {
+		return errors.New("checkpoint block is nil")
+	}
+
+	sRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if sRoot != bytesutil.ToBytes32(block.Block.StateRoot) {
+		return errors.New("checkpoint block's state root does not match checkpoint state")
+	}
+
+	blockRoot, err := ssz.HashTreeRoot(block.Block)
+	if err != nil {
+		return err
+	}
+
+	if err := s.beaconDB.SaveState(ctx, st, blockRoot); err != nil {
+		return err
+	}
+	if err := s.beaconDB.SaveBlock(ctx, block); err != nil {
+		return err
+	}
+
+	s.splitLock.Lock()
+	s.splitInfo = &splitSlotAndRoot{slot: st.Slot(), root: blockRoot}
+	s.splitLock.Unlock()
+
+	anchorEpochSlot := helpers.StartSlot(helpers.SlotToEpoch(st.Slot()))
+	s.setEpochBoundaryRoot(anchorEpochSlot, blockRoot)
+
+	log.WithField("slot", st.Slot()).Info("Seeded state gen from checkpoint sync anchor")
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package stategen
+
+import (
+	"context"
+	"io/ioutil"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// InitFromCheckpointFiles reads an SSZ-encoded finalized BeaconState from
+// stateFilePath and its matching SignedBeaconBlock from blockFilePath, then
+// seeds state gen's hot/cold split point from them via InitFromCheckpoint.
+// This is the integration point for a node started with
+// --checkpoint-state-file and --checkpoint-block-file: it lets the node
+// bypass full historical sync and start producing hot summaries from the
+// anchor forward.
+func (s *State) InitFromCheckpointFiles(ctx context.Context, stateFilePath, blockFilePath string) error {
+	stateRaw, err := ioutil.ReadFile(stateFilePath)
+	if err != nil {
+		return err
+	}
+	pbState := &pb.BeaconState{}
+	if err := ssz.Unmarshal(stateRaw, pbState); err != nil {
+		return err
+	}
+	st, err := state.InitializeFromProto(pbState)
+	if err != nil {
+		return err
+	}
+
+	blockRaw, err := ioutil.ReadFile(blockFilePath)
+	if err != nil {
+		return err
+	}
+	block := &ethpb.SignedBeaconBlock{}
+	if err := ssz.Unmarshal(blockRaw, block); err != nil {
+		return err
+	}
+
+	return s.InitFromCheckpoint(ctx, st, block)
+}
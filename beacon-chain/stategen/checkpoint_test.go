@@ -0,0 +1,103 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+func TestInitFromCheckpoint_SeedsSplitPoint(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	st := &stateTrie.BeaconState{}
+	sRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &ethpb.BeaconBlock{Slot: 0, StateRoot: sRoot[:]}
+	blockRoot, err := ssz.HashTreeRoot(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &ethpb.SignedBeaconBlock{Block: b}
+
+	if err := s.InitFromCheckpoint(ctx, st, block); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.splitInfo.slot != 0 || s.splitInfo.root != blockRoot {
+		t.Error("Split point was not seeded from the checkpoint")
+	}
+	if _, ok := s.epochBoundaryRoot(0); !ok {
+		t.Error("Anchor epoch boundary root should have been cached")
+	}
+}
+
+func TestInitFromCheckpoint_StateRootMismatch(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	st := &stateTrie.BeaconState{}
+	block := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 0, StateRoot: []byte{1, 2, 3}}}
+
+	if err := s.InitFromCheckpoint(ctx, st, block); err == nil {
+		t.Error("Expected an error for a mismatched state root")
+	}
+}
+
+func TestLoadHotStateByRoot_PreAnchorReturnsTypedError(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	// Seed a checkpoint sync anchor at slot 10 so splitSlot() > 0, matching a
+	// node that actually has no history before its anchor. A fresh New(beaconDB)
+	// with splitSlot() == 0 has no anchor at all, so a missing block there is a
+	// genuinely unknown root, not a pre-anchor one; see
+	// TestLoadHotStateByRoot_UnknownRootReturnsGenericError below.
+	anchorState := &stateTrie.BeaconState{}
+	if err := anchorState.SetSlot(10); err != nil {
+		t.Fatal(err)
+	}
+	sRoot, err := anchorState.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anchorBlock := &ethpb.BeaconBlock{Slot: 10, StateRoot: sRoot[:]}
+	signedAnchorBlock := &ethpb.SignedBeaconBlock{Block: anchorBlock}
+	if err := s.InitFromCheckpoint(ctx, anchorState, signedAnchorBlock); err != nil {
+		t.Fatal(err)
+	}
+
+	preAnchorRoot := [32]byte{'p'}
+	if _, err := s.loadHotStateByRoot(ctx, preAnchorRoot); err != ErrNoPreAnchorState {
+		t.Errorf("Expected ErrNoPreAnchorState, got %v", err)
+	}
+}
+
+func TestLoadHotStateByRoot_UnknownRootReturnsGenericError(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	// No checkpoint anchor has ever been seeded, so splitSlot() == 0: state gen
+	// has no pre-anchor history to speak of, and a missing block for this root
+	// just means the caller passed in a root state gen genuinely doesn't know
+	// about, not a pre-anchor one.
+	unknownRoot := [32]byte{'u'}
+	_, err := s.loadHotStateByRoot(ctx, unknownRoot)
+	if err == nil || err == ErrNoPreAnchorState {
+		t.Errorf("Expected a generic error, got %v", err)
+	}
+}
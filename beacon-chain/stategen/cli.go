@@ -0,0 +1,37 @@
+package stategen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/urfave/cli/v2"
+)
+
+// ConfigureFromCLI applies stategen's CLI flags: --stategen-replay-workers for
+// the node's entire runtime, and, if --checkpoint-state-file and
+// --checkpoint-block-file are both set, seeds s from that checkpoint sync
+// anchor so the node can skip full historical sync.
+//
+// This is the integration point node startup is meant to call once, before
+// the node processes any blocks, and where CheckpointStateFlag, CheckpointBlockFlag,
+// and StategenReplayWorkersFlag are meant to be registered on the app's flag set.
+// The node package that owns startup and flag registration doesn't exist in this
+// tree yet, so nothing calls this or registers those flags here; whoever adds
+// that package should call this from there rather than reimplementing flag handling.
+func (s *State) ConfigureFromCLI(ctx context.Context, cliCtx *cli.Context) error {
+	if cliCtx.IsSet(flags.StategenReplayWorkersFlag.Name) {
+		SetReplayWorkers(cliCtx.Int(flags.StategenReplayWorkersFlag.Name))
+	}
+
+	stateFile := cliCtx.String(flags.CheckpointStateFlag.Name)
+	blockFile := cliCtx.String(flags.CheckpointBlockFlag.Name)
+	if stateFile == "" && blockFile == "" {
+		return nil
+	}
+	if stateFile == "" || blockFile == "" {
+		return errors.New("--checkpoint-state-file and --checkpoint-block-file must both be set")
+	}
+
+	return s.InitFromCheckpointFiles(ctx, stateFile, blockFile)
+}
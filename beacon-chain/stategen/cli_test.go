@@ -0,0 +1,59 @@
+package stategen
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/urfave/cli/v2"
+)
+
+func TestConfigureFromCLI_RequiresBothCheckpointFlags(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	s := New(beaconDB)
+
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.CheckpointStateFlag.Name, "state.ssz", "")
+	cliCtx := cli.NewContext(nil, set, nil)
+
+	if err := s.ConfigureFromCLI(context.Background(), cliCtx); err == nil {
+		t.Error("Expected an error when only one checkpoint flag is set")
+	}
+}
+
+func TestConfigureFromCLI_NoFlagsIsNoop(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	s := New(beaconDB)
+
+	set := flag.NewFlagSet("test", 0)
+	cliCtx := cli.NewContext(nil, set, nil)
+
+	if err := s.ConfigureFromCLI(context.Background(), cliCtx); err != nil {
+		t.Errorf("Expected no error with no flags set, got %v", err)
+	}
+}
+
+func TestConfigureFromCLI_SetsReplayWorkers(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	s := New(beaconDB)
+	defer SetReplayWorkers(0)
+
+	set := flag.NewFlagSet("test", 0)
+	set.Int(flags.StategenReplayWorkersFlag.Name, 0, "")
+	if err := set.Set(flags.StategenReplayWorkersFlag.Name, "7"); err != nil {
+		t.Fatal(err)
+	}
+	cliCtx := cli.NewContext(nil, set, nil)
+
+	if err := s.ConfigureFromCLI(context.Background(), cliCtx); err != nil {
+		t.Fatal(err)
+	}
+	if got := numReplayWorkers(); got != 7 {
+		t.Errorf("numReplayWorkers() = %d, want 7", got)
+	}
+}
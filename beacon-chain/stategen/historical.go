@@ -0,0 +1,111 @@
+package stategen
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// ErrReplayBudgetExceeded is returned by HistoricalStateBySlot when the
+// caller-supplied replay budget (WithMaxReplayBlocks and/or WithDeadline) is
+// exhausted before the target slot is reached.
+var ErrReplayBudgetExceeded = errors.New("stategen: replay budget exceeded before reaching target slot")
+
+// historicalOpts holds the optional budget a caller can place on a single
+// HistoricalStateBySlot call. maxReplayBlocks is a pointer so a caller-supplied
+// 0 (replay no blocks at all) is distinguishable from the option never being
+// set (unlimited).
+type historicalOpts struct {
+	maxReplayBlocks *int
+	deadline        time.Time
+}
+
+// HistoricalOpt configures a call to HistoricalStateBySlot.
+type HistoricalOpt func(*historicalOpts)
+
+// WithMaxReplayBlocks caps the number of blocks HistoricalStateBySlot will
+// replay before aborting with ErrReplayBudgetExceeded. A value of 0 means no
+// blocks may be replayed at all, it does not mean unlimited.
+func WithMaxReplayBlocks(n int) HistoricalOpt {
+	return func(o *historicalOpts) {
+		o.maxReplayBlocks = &n
+	}
+}
+
+// WithDeadline aborts HistoricalStateBySlot with ErrReplayBudgetExceeded if
+// replay is still running past t.
+func WithDeadline(t time.Time) HistoricalOpt {
+	return func(o *historicalOpts) {
+		o.deadline = t
+	}
+}
+
+// HistoricalStateBySlot returns the canonical post-state at slot, spanning both
+// the cold and hot regions of the DB transparently. It locates the nearest
+// saved state at-or-before slot via the finalized slot index, replays forward
+// to slot, and enforces any caller-supplied replay budget, aborting with
+// ErrReplayBudgetExceeded rather than blocking indefinitely on a large gap.
+// The produced state is cached in the hot-state LRU keyed by (blockRoot, slot),
+// so repeated Beacon API /eth/v1/debug/beacon/states/{slot} queries for the
+// same slot are cheap.
+func (s *State) HistoricalStateBySlot(ctx context.Context, slot uint64, opts ...HistoricalOpt) (*state.BeaconState, error) {
+	o := &historicalOpts{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	lastRoot, lastSlot, err := s.beaconDB.HighestCanonicalRootBefore(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := historicalCacheKey{root: lastRoot, slot: slot}
+	if cached, ok := s.stateCache.getHistorical(cacheKey); ok {
+		return cached, nil
+	}
+
+	baseState, err := s.beaconDB.State(ctx, lastRoot)
+	if err != nil {
+		return nil, err
+	}
+	if baseState == nil {
+		return nil, ErrNoPreAnchorState
+	}
+
+	if lastSlot == slot {
+		s.stateCache.putHistorical(cacheKey, baseState)
+		return baseState, nil
+	}
+
+	blks, err := s.LoadBlocks(ctx, baseState.Slot()+1, slot, lastRoot)
+	if err != nil {
+		return nil, err
+	}
+	if o.maxReplayBlocks != nil && len(blks) > *o.maxReplayBlocks {
+		return nil, ErrReplayBudgetExceeded
+	}
+
+	replayCtx := ctx
+	if !o.deadline.IsZero() {
+		if time.Now().After(o.deadline) {
+			return nil, ErrReplayBudgetExceeded
+		}
+		var cancel context.CancelFunc
+		replayCtx, cancel = context.WithDeadline(ctx, o.deadline)
+		defer cancel()
+	}
+
+	st, err := s.ReplayBlocksParallel(replayCtx, baseState, blks, slot)
+	if err != nil {
+		if replayCtx.Err() == context.DeadlineExceeded {
+			return nil, ErrReplayBudgetExceeded
+		}
+		return nil, err
+	}
+
+	s.stateCache.putHistorical(cacheKey, st)
+
+	return st, nil
+}
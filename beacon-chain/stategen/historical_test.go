@@ -0,0 +1,102 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// seedHistoricalChain saves a chain with one block on every even slot in
+// [0, numSlots), leaving odd slots as skip slots, with both an empty state and
+// a finalized slot index entry at every saved slot, and returns the roots keyed
+// by slot.
+func seedHistoricalChain(t *testing.T, beaconDB interface {
+	SaveBlock(context.Context, *ethpb.SignedBeaconBlock) error
+	SaveState(context.Context, *stateTrie.BeaconState, [32]byte) error
+	SaveFinalizedSlotIndex(context.Context, uint64, [32]byte) error
+}, numSlots uint64) map[uint64][32]byte {
+	ctx := context.Background()
+	roots := make(map[uint64][32]byte)
+	var parentRoot [32]byte
+	for slot := uint64(0); slot < numSlots; slot++ {
+		if slot%2 == 1 {
+			continue // simulate a skip slot
+		}
+		blk := &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}
+		r, err := ssz.HashTreeRoot(blk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := beaconDB.SaveBlock(ctx, &ethpb.SignedBeaconBlock{Block: blk}); err != nil {
+			t.Fatal(err)
+		}
+		if err := beaconDB.SaveState(ctx, &stateTrie.BeaconState{}, r); err != nil {
+			t.Fatal(err)
+		}
+		if err := beaconDB.SaveFinalizedSlotIndex(ctx, slot, r); err != nil {
+			t.Fatal(err)
+		}
+		roots[slot] = r
+		parentRoot = r
+	}
+	return roots
+}
+
+func TestHistoricalStateBySlot_ExactIndexedSlot(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	roots := seedHistoricalChain(t, beaconDB, 10)
+
+	st, err := s.HistoricalStateBySlot(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st == nil {
+		t.Fatal("Expected a non-nil state")
+	}
+
+	// A second lookup for the same slot should be served from the cache.
+	cacheKey := historicalCacheKey{root: roots[4], slot: 4}
+	if _, ok := s.stateCache.getHistorical(cacheKey); !ok {
+		t.Error("Expected the produced state to be cached")
+	}
+}
+
+func TestHistoricalStateBySlot_BudgetExceeded(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	seedHistoricalChain(t, beaconDB, 10)
+
+	// Slot 9 resolves to the last indexed (non-skip) slot, 8, whose seeded state
+	// is empty (slot 0), so replaying to slot 9 loads the blocks after slot 0 up
+	// to slot 8. A budget of zero can never cover that.
+	_, err := s.HistoricalStateBySlot(ctx, 9, WithMaxReplayBlocks(0))
+	if err != ErrReplayBudgetExceeded {
+		t.Errorf("HistoricalStateBySlot() err = %v, want ErrReplayBudgetExceeded", err)
+	}
+}
+
+func TestHistoricalStateBySlot_DeadlineExceeded(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	seedHistoricalChain(t, beaconDB, 10)
+
+	_, err := s.HistoricalStateBySlot(ctx, 9, WithDeadline(time.Now().Add(-time.Second)))
+	if err != ErrReplayBudgetExceeded {
+		t.Errorf("HistoricalStateBySlot() err = %v, want ErrReplayBudgetExceeded", err)
+	}
+}
@@ -16,8 +16,12 @@ import (
 )
 
 // HotStateExists returns true if the corresponding state of the input block either
-// exists in the DB or it can be generated by state gen.
+// exists in the DB or it can be generated by state gen. It returns false for roots
+// that precede the checkpoint sync anchor, where state gen has no history at all.
 func (s *State) HotStateExists(ctx context.Context, blockRoot [32]byte) bool {
+	if s.stateCache.has(blockRoot) {
+		return true
+	}
 	return s.beaconDB.HasHotStateSummary(ctx, blockRoot)
 }
 
@@ -50,7 +54,9 @@ func (s *State) saveHotState(ctx context.Context, blockRoot [32]byte, state *sta
 	}
 	hotSummarySaved.Inc()
 
-	// Store the state in the cache.
+	// Store the state in the cache so subsequent intermediate slot lookups
+	// for this block root don't need to hit the DB or replay blocks.
+	s.stateCache.put(blockRoot, state)
 
 	return nil
 }
@@ -58,13 +64,25 @@ func (s *State) saveHotState(ctx context.Context, blockRoot [32]byte, state *sta
 // This loads a post finalized beacon state from the hot section of the DB. If necessary it will
 // replay blocks from the nearest epoch boundary.
 func (s *State) loadHotStateByRoot(ctx context.Context, blockRoot [32]byte) (*state.BeaconState, error) {
-	// Load the cache
+	// Load the state from the cache if it exists to avoid re-fetching the
+	// boundary state from disk and replaying blocks on top of it.
+	if cached, ok := s.stateCache.get(blockRoot); ok {
+		return cached, nil
+	}
 
 	summary, err := s.beaconDB.HotStateSummary(ctx, blockRoot)
 	if err != nil {
 		return nil, err
 	}
 	if summary == nil {
+		// A node bootstrapped via checkpoint sync (splitSlot > 0) never has a
+		// block for a root that precedes its anchor, that's expected rather
+		// than corrupt state. A node with no anchor (splitSlot == 0) has no
+		// pre-anchor history to speak of, so a missing block there means the
+		// caller passed a root state gen genuinely doesn't know about.
+		if s.splitSlot() > 0 && !s.beaconDB.HasBlock(ctx, blockRoot) {
+			return nil, ErrNoPreAnchorState
+		}
 		return nil, errors.New("nil hot state summary")
 	}
 	targetSlot := summary.Slot
@@ -86,13 +104,13 @@ func (s *State) loadHotStateByRoot(ctx context.Context, blockRoot [32]byte) (*st
 		if err != nil {
 			return nil, err
 		}
-		hotState, err = s.ReplayBlocks(ctx, boundaryState, blks, targetSlot)
+		hotState, err = s.ReplayBlocksParallel(ctx, boundaryState, blks, targetSlot)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// Save the cache
+	s.stateCache.put(blockRoot, hotState)
 
 	return hotState, nil
 }
@@ -123,7 +141,7 @@ func (s *State) loadHotIntermediateStateWithSlot(ctx context.Context, slot uint6
 	if err != nil {
 		return nil, err
 	}
-	return s.ReplayBlocks(ctx, epochBoundaryState, replayBlks, slot)
+	return s.ReplayBlocksParallel(ctx, epochBoundaryState, replayBlks, slot)
 }
 
 // This loads the epoch boundary root of a given state based on the state slot.
@@ -133,7 +151,7 @@ func (s *State) loadEpochBoundaryRoot(ctx context.Context, blockRoot [32]byte, s
 	epochBoundarySlot := helpers.CurrentEpoch(state) * params.BeaconConfig().SlotsPerEpoch
 
 	// Node first checks if epoch boundary root already exists in cache.
-	r, ok := s.epochBoundarySlotToRoot[epochBoundarySlot]
+	r, ok := s.epochBoundaryRoot(epochBoundarySlot)
 	if ok {
 		return r, nil
 	}
@@ -189,13 +207,15 @@ func (s *State) loadEpochBoundaryRoot(ctx context.Context, blockRoot [32]byte, s
 // This finds the last valid state from searching backwards starting at input slot
 // and returns the root of the block which is used to process the state.
 func (s *State) handleLastValidState(ctx context.Context, slot uint64) ([32]byte, error) {
-	filter := filters.NewFilter().SetStartSlot(s.splitInfo.slot).SetEndSlot(slot)
-	// We know the epoch boundary root will be the last index using the filter.
-	rs, err := s.beaconDB.BlockRoots(ctx, filter)
+	// The finalized slot index turns this lookup into a single seek instead of
+	// a BlockRoots range scan over the whole epoch.
+	lastRoot, lastSlot, err := s.beaconDB.HighestCanonicalRootBefore(ctx, slot)
 	if err != nil {
 		return [32]byte{}, err
 	}
-	lastRoot := rs[len(rs)-1]
+	if lastSlot < s.splitSlot() {
+		return [32]byte{}, ErrNoPreAnchorState
+	}
 
 	// Node replays to get the last valid state which has a block.
 	// Then saves the state in the DB.
@@ -208,7 +228,7 @@ func (s *State) handleLastValidState(ctx context.Context, slot uint64) ([32]byte
 	if err != nil {
 		return [32]byte{}, err
 	}
-	startState, err = s.ReplayBlocks(ctx, startState, blks, slot)
+	startState, err = s.ReplayBlocksParallel(ctx, startState, blks, slot)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -222,18 +242,15 @@ func (s *State) handleLastValidState(ctx context.Context, slot uint64) ([32]byte
 // This finds the last valid block from searching backwards starting at input slot
 // and returns the root of the block.
 func (s *State) getLastValidBlock(ctx context.Context, targetSlot uint64) ([32]byte, uint64, error) {
-	filter := filters.NewFilter().SetStartSlot(s.splitInfo.slot).SetEndSlot(targetSlot)
-	// We know the epoch boundary root will be the last index using the filter.
-	rs, err := s.beaconDB.BlockRoots(ctx, filter)
+	// The finalized slot index turns this lookup into a single seek instead of
+	// a BlockRoots range scan over the whole epoch.
+	lastRoot, lastSlot, err := s.beaconDB.HighestCanonicalRootBefore(ctx, targetSlot)
 	if err != nil {
 		return [32]byte{}, 0, err
 	}
-	lastRoot := rs[len(rs)-1]
-
-	b, err := s.beaconDB.Block(ctx, lastRoot)
-	if err != nil {
-		return [32]byte{}, 0, err
+	if lastSlot < s.splitSlot() {
+		return [32]byte{}, 0, ErrNoPreAnchorState
 	}
 
-	return lastRoot, b.Block.Slot, nil
+	return lastRoot, lastSlot, nil
 }
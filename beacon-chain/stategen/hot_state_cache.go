@@ -0,0 +1,94 @@
+package stategen
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// defaultHotStateCacheSize defines the max number of states the hot state
+// cache can hold at once. Nimbus caps its equivalent EpochRef cache at a
+// similarly small number of entries, which is enough to cover the states
+// touched by a handful of concurrent in-flight epochs without growing
+// unbounded memory usage.
+const defaultHotStateCacheSize = 32
+
+// hotStateCache is a thread-safe LRU cache that holds in-memory copies of
+// recently computed hot states, keyed by block root. It lets repeated
+// lookups for the same root skip the boundary state fetch and block replay
+// that loadHotStateByRoot would otherwise perform on every call.
+type hotStateCache struct {
+	cache *lru.Cache
+}
+
+// newHotStateCache returns a hot state cache with the default capacity.
+func newHotStateCache() *hotStateCache {
+	return newHotStateCacheWithSize(defaultHotStateCacheSize)
+}
+
+// newHotStateCacheWithSize returns a hot state cache with the given capacity.
+func newHotStateCacheWithSize(size int) *hotStateCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		// Only possible if size <= 0, which is a programmer error.
+		panic(err)
+	}
+	return &hotStateCache{cache: cache}
+}
+
+// get returns the cached state for blockRoot, and whether it was found. The
+// returned state is a copy so the caller is free to mutate it.
+func (c *hotStateCache) get(blockRoot [32]byte) (*state.BeaconState, bool) {
+	item, exists := c.cache.Get(blockRoot)
+	if !exists || item == nil {
+		hotStateCacheMiss.Inc()
+		return nil, false
+	}
+	hotStateCacheHit.Inc()
+	return item.(*state.BeaconState).Copy(), true
+}
+
+// put inserts a copy of st into the cache, keyed by blockRoot, evicting the
+// least recently used entry if the cache is already at capacity.
+func (c *hotStateCache) put(blockRoot [32]byte, st *state.BeaconState) {
+	evicted := c.cache.Add(blockRoot, st.Copy())
+	if evicted {
+		hotStateCacheEvict.Inc()
+	}
+}
+
+// has returns true if blockRoot has a cached state.
+func (c *hotStateCache) has(blockRoot [32]byte) bool {
+	return c.cache.Contains(blockRoot)
+}
+
+// delete removes blockRoot's entry from the cache, if any.
+func (c *hotStateCache) delete(blockRoot [32]byte) {
+	c.cache.Remove(blockRoot)
+}
+
+// historicalCacheKey identifies a state produced for a specific historical
+// slot, as opposed to the plain block-root keys used for hot states. The two
+// key shapes coexist in the same underlying LRU without colliding.
+type historicalCacheKey struct {
+	root [32]byte
+	slot uint64
+}
+
+// getHistorical returns the cached state for key, and whether it was found.
+func (c *hotStateCache) getHistorical(key historicalCacheKey) (*state.BeaconState, bool) {
+	item, exists := c.cache.Get(key)
+	if !exists || item == nil {
+		hotStateCacheMiss.Inc()
+		return nil, false
+	}
+	hotStateCacheHit.Inc()
+	return item.(*state.BeaconState).Copy(), true
+}
+
+// putHistorical inserts a copy of st into the cache, keyed by key.
+func (c *hotStateCache) putHistorical(key historicalCacheKey, st *state.BeaconState) {
+	evicted := c.cache.Add(key, st.Copy())
+	if evicted {
+		hotStateCacheEvict.Inc()
+	}
+}
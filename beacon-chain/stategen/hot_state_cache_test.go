@@ -0,0 +1,49 @@
+package stategen
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+func TestHotStateCache_RoundTrip(t *testing.T) {
+	c := newHotStateCache()
+	r := [32]byte{'A'}
+
+	if _, exists := c.get(r); exists {
+		t.Error("Empty cache should not have the state")
+	}
+	if c.has(r) {
+		t.Error("Empty cache should not have the state")
+	}
+
+	st := &state.BeaconState{}
+	c.put(r, st)
+
+	if !c.has(r) {
+		t.Error("Cache should have the state after put")
+	}
+	cached, exists := c.get(r)
+	if !exists {
+		t.Fatal("Expected cached state to exist")
+	}
+	if cached == st {
+		t.Error("Cache should return a copy, not the original pointer")
+	}
+}
+
+func TestHotStateCache_Eviction(t *testing.T) {
+	c := newHotStateCacheWithSize(2)
+
+	r1, r2, r3 := [32]byte{'A'}, [32]byte{'B'}, [32]byte{'C'}
+	c.put(r1, &state.BeaconState{})
+	c.put(r2, &state.BeaconState{})
+	c.put(r3, &state.BeaconState{})
+
+	if c.has(r1) {
+		t.Error("Oldest entry should have been evicted once the cache exceeded its capacity")
+	}
+	if !c.has(r2) || !c.has(r3) {
+		t.Error("Most recently added entries should still be cached")
+	}
+}
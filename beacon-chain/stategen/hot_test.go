@@ -0,0 +1,59 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// TestLoadHotStateByRoot_CachesAcrossCalls drives loadHotStateByRoot twice for
+// the same root and confirms the second call is served entirely from the hot
+// state cache: it deletes the summary and state backing the first call out of
+// the DB in between, so a second DB fetch or replay would fail.
+func TestLoadHotStateByRoot_CachesAcrossCalls(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	b := &ethpb.BeaconBlock{Slot: 0}
+	r, err := ssz.HashTreeRoot(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveBlock(ctx, &ethpb.SignedBeaconBlock{Block: b}); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveState(ctx, &stateTrie.BeaconState{}, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveHotStateSummary(ctx, &pb.HotStateSummary{
+		Slot:         0,
+		LatestRoot:   r[:],
+		BoundaryRoot: r[:],
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.loadHotStateByRoot(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pull the rug out from under a second DB-backed lookup: if the next call
+	// isn't served from the cache, it has nothing left to load and replay.
+	if err := beaconDB.DeleteHotStateSummary(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.DeleteState(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.loadHotStateByRoot(ctx, r); err != nil {
+		t.Fatalf("Second call should have been served from the cache, got error: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+)
+
+// seedMultiEpochSkipChain saves a chain spanning numEpochs epochs where every
+// other slot is a skip slot, the scenario where the old BlockRoots range scan
+// degraded the most: it had to walk an entire epoch of entries to find the
+// last non-skip slot before an epoch boundary.
+func seedMultiEpochSkipChain(b *testing.B, db interface {
+	SaveBlock(context.Context, *ethpb.SignedBeaconBlock) error
+	SaveFinalizedSlotIndex(context.Context, uint64, [32]byte) error
+}, numEpochs int) {
+	ctx := context.Background()
+	var parentRoot [32]byte
+	const slotsPerEpoch = uint64(32)
+	for slot := uint64(0); slot < uint64(numEpochs)*slotsPerEpoch; slot++ {
+		if slot%2 == 1 {
+			continue // simulate a skip slot
+		}
+		blk := &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}
+		r, err := ssz.HashTreeRoot(blk)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := db.SaveBlock(ctx, &ethpb.SignedBeaconBlock{Block: blk}); err != nil {
+			b.Fatal(err)
+		}
+		if err := db.SaveFinalizedSlotIndex(ctx, slot, r); err != nil {
+			b.Fatal(err)
+		}
+		parentRoot = r
+	}
+}
+
+func BenchmarkBlockRootsRangeScan(b *testing.B) {
+	beaconDB := testDB.SetupDB(b)
+	defer testDB.TeardownDB(b, beaconDB)
+	seedMultiEpochSkipChain(b, beaconDB, 8)
+	ctx := context.Background()
+	f := filters.NewFilter().SetStartSlot(0).SetEndSlot(8 * 32)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := beaconDB.BlockRoots(ctx, f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHighestCanonicalRootBefore(b *testing.B) {
+	beaconDB := testDB.SetupDB(b)
+	defer testDB.TeardownDB(b, beaconDB)
+	seedMultiEpochSkipChain(b, beaconDB, 8)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := beaconDB.HighestCanonicalRootBefore(ctx, 8*32); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
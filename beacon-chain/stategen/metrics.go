@@ -0,0 +1,37 @@
+package stategen
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	hotStateSaved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "hot_state_saved_total",
+		Help: "The total number of times a hot state is saved",
+	})
+	hotSummarySaved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "hot_state_summary_saved_total",
+		Help: "The total number of times a hot state summary is saved",
+	})
+	hotStateCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "hot_state_cache_hit_total",
+		Help: "The total number of hot state requests that hit the cache",
+	})
+	hotStateCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "hot_state_cache_miss_total",
+		Help: "The total number of hot state requests that miss the cache",
+	})
+	hotStateCacheEvict = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "hot_state_cache_evict_total",
+		Help: "The total number of hot states evicted from the cache",
+	})
+	replayBlocksPerSecond = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "stategen_replay_blocks_per_second",
+		Help: "The rate at which ReplayBlocksParallel replays blocks",
+	})
+	replaySigsPerSecond = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "stategen_replay_sigs_per_second",
+		Help: "The rate at which ReplayBlocksParallel verifies block signatures",
+	})
+)
@@ -0,0 +1,112 @@
+package stategen
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+// MigrateToCold prunes hot state bookkeeping once finalization advances past the
+// current split point. It walks every hot state summary between the old and new
+// split slots, saves the state of every canonical block among them to the cold
+// section of the DB and records it in the finalized slot index, and deletes the hot summaries plus any off-canonical (orphaned)
+// branches that can no longer be reached from the finalized chain. This mirrors
+// the pruning-on-finalization approach used by other clients to keep steady-state
+// memory and hot DB usage bounded regardless of chain length.
+//
+// MigrateToCold is the integration point the fork choice service's finalization
+// callback is meant to call; that service doesn't exist in this tree yet, so no
+// caller is wired up here. Whoever adds the blockchain package's finalization
+// handling should call this from there rather than reimplementing pruning.
+func (s *State) MigrateToCold(ctx context.Context, finalizedRoot [32]byte, finalizedSlot uint64) error {
+	s.splitLock.Lock()
+	defer s.splitLock.Unlock()
+
+	// Finalization hasn't advanced since the last migration, nothing to prune.
+	if finalizedSlot <= s.splitInfo.slot {
+		return nil
+	}
+
+	canonicalRoots, err := s.canonicalRootsSince(ctx, finalizedRoot, s.splitInfo.slot)
+	if err != nil {
+		return err
+	}
+
+	filter := filters.NewFilter().SetStartSlot(s.splitInfo.slot).SetEndSlot(finalizedSlot)
+	roots, err := s.beaconDB.BlockRoots(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range roots {
+		summary, err := s.beaconDB.HotStateSummary(ctx, r)
+		if err != nil {
+			return err
+		}
+		if summary == nil {
+			continue
+		}
+
+		if canonicalRoots[r] {
+			st, err := s.loadHotStateByRoot(ctx, r)
+			if err != nil {
+				return err
+			}
+			if err := s.beaconDB.SaveState(ctx, st, r); err != nil {
+				return err
+			}
+			if err := s.beaconDB.SaveFinalizedSlotIndex(ctx, summary.Slot, r); err != nil {
+				return err
+			}
+		} else {
+			// This branch never became canonical and is now below the
+			// finalized slot, it can never be built on top of again.
+			if err := s.beaconDB.DeleteBlock(ctx, r); err != nil {
+				return err
+			}
+			if err := s.beaconDB.DeleteState(ctx, r); err != nil {
+				return err
+			}
+		}
+
+		if err := s.beaconDB.DeleteHotStateSummary(ctx, r); err != nil {
+			return err
+		}
+		s.stateCache.delete(r)
+	}
+
+	// Drop epoch boundary cache entries at or below the new split point,
+	// they'll never be looked up again once their slot is finalized.
+	s.epochBoundaryLock.Lock()
+	for slot := range s.epochBoundarySlotToRoot {
+		if slot <= finalizedSlot {
+			delete(s.epochBoundarySlotToRoot, slot)
+		}
+	}
+	s.epochBoundaryLock.Unlock()
+
+	s.splitInfo = &splitSlotAndRoot{slot: finalizedSlot, root: finalizedRoot}
+
+	return nil
+}
+
+// canonicalRootsSince walks back from tipRoot to lowerBoundSlot and returns the
+// set of block roots on that chain. Anything in the pruning range that isn't in
+// this set is an orphaned, off-canonical branch.
+func (s *State) canonicalRootsSince(ctx context.Context, tipRoot [32]byte, lowerBoundSlot uint64) (map[[32]byte]bool, error) {
+	canonical := make(map[[32]byte]bool)
+	root := tipRoot
+	for {
+		canonical[root] = true
+		b, err := s.beaconDB.Block(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil || b.Block.Slot <= lowerBoundSlot {
+			break
+		}
+		root = bytesutil.ToBytes32(b.Block.ParentRoot)
+	}
+	return canonical, nil
+}
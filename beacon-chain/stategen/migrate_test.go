@@ -0,0 +1,137 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// migrateChain builds a simple canonical chain B0 - B1 - B2 - B3, saving a
+// hot state summary and state for each block, and returns the block roots in
+// order.
+func migrateChain(t *testing.T, db interface {
+	SaveBlock(context.Context, *ethpb.SignedBeaconBlock) error
+	SaveState(context.Context, *stateTrie.BeaconState, [32]byte) error
+	SaveHotStateSummary(context.Context, *pb.HotStateSummary) error
+}) [][32]byte {
+	roots := make([][32]byte, 0, 4)
+	var parentRoot [32]byte
+	for slot := uint64(0); slot < 4; slot++ {
+		b := &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}
+		r, err := ssz.HashTreeRoot(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveBlock(context.Background(), &ethpb.SignedBeaconBlock{Block: b}); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveState(context.Background(), &stateTrie.BeaconState{}, r); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveHotStateSummary(context.Background(), &pb.HotStateSummary{
+			Slot:         slot,
+			LatestRoot:   r[:],
+			BoundaryRoot: r[:],
+		}); err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, r)
+		parentRoot = r
+	}
+	return roots
+}
+
+func TestMigrateToCold_CanonicalRetention(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	roots := migrateChain(t, beaconDB)
+
+	if err := s.MigrateToCold(ctx, roots[3], 3); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range roots {
+		if beaconDB.HasHotStateSummary(ctx, r) {
+			t.Error("Hot state summary should have been pruned after migration")
+		}
+		st, err := beaconDB.State(ctx, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st == nil {
+			t.Error("Canonical state should have been promoted to the cold section")
+		}
+	}
+}
+
+func TestMigrateToCold_OrphanBranchDeletion(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	roots := migrateChain(t, beaconDB)
+
+	// Fork off of B1 with an orphan block that never becomes canonical.
+	orphan := &ethpb.BeaconBlock{Slot: 2, ParentRoot: roots[1][:], StateRoot: []byte{'o'}}
+	orphanRoot, err := ssz.HashTreeRoot(orphan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveBlock(ctx, &ethpb.SignedBeaconBlock{Block: orphan}); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveState(ctx, &stateTrie.BeaconState{}, orphanRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveHotStateSummary(ctx, &pb.HotStateSummary{
+		Slot:         2,
+		LatestRoot:   orphanRoot[:],
+		BoundaryRoot: orphanRoot[:],
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.MigrateToCold(ctx, roots[3], 3); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := beaconDB.Block(ctx, orphanRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b != nil {
+		t.Error("Orphan block should have been deleted during migration")
+	}
+	if beaconDB.HasHotStateSummary(ctx, orphanRoot) {
+		t.Error("Orphan hot state summary should have been deleted during migration")
+	}
+}
+
+func TestMigrateToCold_Idempotent(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	s := New(beaconDB)
+
+	roots := migrateChain(t, beaconDB)
+
+	if err := s.MigrateToCold(ctx, roots[3], 3); err != nil {
+		t.Fatal(err)
+	}
+	// Finalizing the same checkpoint again should be a no-op, not an error.
+	if err := s.MigrateToCold(ctx, roots[3], 3); err != nil {
+		t.Fatal(err)
+	}
+	if s.splitInfo.slot != 3 || s.splitInfo.root != roots[3] {
+		t.Error("Split point should remain at the finalized checkpoint")
+	}
+}
@@ -0,0 +1,144 @@
+package stategen
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// replayWorkers controls how many goroutines verify block signatures
+// concurrently while ReplayBlocksParallel executes state transitions
+// serially on the calling goroutine. It's set from the
+// --stategen-replay-workers flag; zero falls back to GOMAXPROCS.
+var replayWorkers = 0
+
+// SetReplayWorkers overrides the number of signature verification workers
+// used by ReplayBlocksParallel. A value <= 0 falls back to GOMAXPROCS.
+func SetReplayWorkers(n int) {
+	replayWorkers = n
+}
+
+func numReplayWorkers() int {
+	if replayWorkers > 0 {
+		return replayWorkers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// ReplayBlocksParallel replays blks on top of st up to and including
+// targetSlot. State transitions are inherently sequential and still happen
+// one block at a time on the calling goroutine, but the comparatively
+// expensive BLS signature verification for every block is launched
+// speculatively across a worker pool ahead of when each block's transition
+// needs it, instead of interleaved with each transition. Each block's
+// verification result is read just before that block's transition; if it
+// failed, replay stops there, cancels verification of blocks not yet started,
+// and returns that error. Blocks earlier in blks are still replayed first, so
+// a failure is only reported once replay reaches the failing block.
+func (s *State) ReplayBlocksParallel(ctx context.Context, st *state.BeaconState, blks []*ethpb.SignedBeaconBlock, targetSlot uint64) (*state.BeaconState, error) {
+	if len(blks) == 0 {
+		return s.ReplayBlocks(ctx, st, blks, targetSlot)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sigResults := make([]chan error, len(blks))
+	for i := range sigResults {
+		sigResults[i] = make(chan error, 1)
+	}
+
+	jobs := make(chan int, len(blks))
+	workers := numReplayWorkers()
+	if workers > len(blks) {
+		workers = len(blks)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				select {
+				case <-ctx.Done():
+					sigResults[i] <- ctx.Err()
+				default:
+					sigResults[i] <- verifyBlockSignatures(blks[i])
+				}
+			}
+		}()
+	}
+	for i := range blks {
+		jobs <- i
+	}
+	close(jobs)
+
+	start := time.Now()
+	replayed := st
+	for i, b := range blks {
+		// Re-checked on every iteration, not just once up front, so a caller
+		// deadline is actually enforced across a long-running replay instead
+		// of only gating whether replay starts at all.
+		if err := ctx.Err(); err != nil {
+			cancel()
+			wg.Wait()
+			return nil, err
+		}
+		if err := <-sigResults[i]; err != nil {
+			cancel()
+			wg.Wait()
+			return nil, err
+		}
+		var err error
+		replayed, err = s.ReplayBlocks(ctx, replayed, []*ethpb.SignedBeaconBlock{b}, b.Block.Slot)
+		if err != nil {
+			cancel()
+			wg.Wait()
+			return nil, err
+		}
+	}
+	wg.Wait()
+
+	// The loop above only replays state transitions up to the last loaded
+	// block's own slot. If targetSlot has trailing empty (skip) slots past
+	// that block, advance through them here, mirroring what the serial
+	// ReplayBlocks(..., targetSlot) this replaces does in one call.
+	if replayed.Slot() < targetSlot {
+		var err error
+		replayed, err = s.ReplayBlocks(ctx, replayed, nil, targetSlot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if elapsed := time.Since(start).Seconds(); elapsed > 0 {
+		replayBlocksPerSecond.Set(float64(len(blks)) / elapsed)
+		// One proposer signature plus one signature per attestation.
+		sigs := 0
+		for _, b := range blks {
+			sigs += 1 + len(b.Block.Body.Attestations)
+		}
+		replaySigsPerSecond.Set(float64(sigs) / elapsed)
+	}
+
+	return replayed, nil
+}
+
+// verifyBlockSignatures verifies a block's proposer signature and the
+// signatures of its contained attestations, using batched BLS aggregate
+// verification for the attestations where possible. These checks are pure
+// functions of the block and don't depend on the sequential state
+// transition, so they're safe to run ahead of when the transition for this
+// block actually occurs.
+func verifyBlockSignatures(blk *ethpb.SignedBeaconBlock) error {
+	if err := blocks.VerifyBlockSignature(blk); err != nil {
+		return err
+	}
+	return blocks.VerifyAttestationsSignatures(blk.Block.Body.Attestations)
+}
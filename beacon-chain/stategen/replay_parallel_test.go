@@ -0,0 +1,24 @@
+package stategen
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestNumReplayWorkers_DefaultsToGOMAXPROCS(t *testing.T) {
+	SetReplayWorkers(0)
+	defer SetReplayWorkers(0)
+
+	if got, want := numReplayWorkers(), runtime.GOMAXPROCS(0); got != want {
+		t.Errorf("numReplayWorkers() = %d, want %d", got, want)
+	}
+}
+
+func TestNumReplayWorkers_Override(t *testing.T) {
+	SetReplayWorkers(4)
+	defer SetReplayWorkers(0)
+
+	if got := numReplayWorkers(); got != 4 {
+		t.Errorf("numReplayWorkers() = %d, want 4", got)
+	}
+}
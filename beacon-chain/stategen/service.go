@@ -0,0 +1,60 @@
+package stategen
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+)
+
+// State is a cache of hot and cold states along with the epoch boundary
+// bookkeeping needed to replay blocks on top of them. It is the single
+// access point the rest of the beacon chain service uses to fetch a
+// `state.BeaconState` at an arbitrary block root or slot.
+type State struct {
+	beaconDB                db.Database
+	splitInfo               *splitSlotAndRoot
+	splitLock               sync.RWMutex
+	epochBoundarySlotToRoot map[uint64][32]byte
+	epochBoundaryLock       sync.RWMutex
+	stateCache              *hotStateCache
+}
+
+// splitSlotAndRoot tracks the slot and block root of the hot/cold split point,
+// i.e. the most recently finalized checkpoint that state gen has processed.
+type splitSlotAndRoot struct {
+	slot uint64
+	root [32]byte
+}
+
+// New returns a new state management object.
+func New(db db.Database) *State {
+	return &State{
+		beaconDB:                db,
+		splitInfo:               &splitSlotAndRoot{},
+		epochBoundarySlotToRoot: make(map[uint64][32]byte),
+		stateCache:              newHotStateCache(),
+	}
+}
+
+// splitSlot returns the slot of the current hot/cold split point.
+func (s *State) splitSlot() uint64 {
+	s.splitLock.RLock()
+	defer s.splitLock.RUnlock()
+	return s.splitInfo.slot
+}
+
+// epochBoundaryRoot returns the cached block root for the given epoch
+// boundary slot, if one has been recorded.
+func (s *State) epochBoundaryRoot(slot uint64) ([32]byte, bool) {
+	s.epochBoundaryLock.RLock()
+	defer s.epochBoundaryLock.RUnlock()
+	r, ok := s.epochBoundarySlotToRoot[slot]
+	return r, ok
+}
+
+// setEpochBoundaryRoot caches the block root for the given epoch boundary slot.
+func (s *State) setEpochBoundaryRoot(slot uint64, root [32]byte) {
+	s.epochBoundaryLock.Lock()
+	defer s.epochBoundaryLock.Unlock()
+	s.epochBoundarySlotToRoot[slot] = root
+}